@@ -0,0 +1,127 @@
+// Package ast defines the abstract syntax tree produced by the parser.
+package ast
+
+import "github.com/vomolo/interpreter/token"
+
+// Node is implemented by every AST node.
+type Node interface {
+	TokenLiteral() string
+}
+
+// Trivia holds the comment and whitespace tokens immediately surrounding a
+// node, captured when the lexer is run with PreserveTrivia(true). It is
+// embedded in every node type so a future formatter can round-trip source
+// exactly instead of only re-serializing the significant tokens. The parser
+// does not populate these fields yet; that wiring lands in a later change.
+type Trivia struct {
+	LeadingTrivia  []token.Token
+	TrailingTrivia []token.Token
+}
+
+// Statement is implemented by AST nodes that represent a statement.
+type Statement interface {
+	Node
+	statementNode()
+}
+
+// Expression is implemented by AST nodes that represent an expression.
+type Expression interface {
+	Node
+	expressionNode()
+}
+
+// Identifier is a reference to a bound name, e.g. `x`.
+type Identifier struct {
+	Token token.Token
+	Name  string
+
+	Trivia
+}
+
+func (i *Identifier) expressionNode()      {}
+func (i *Identifier) TokenLiteral() string { return i.Token.Lexeme }
+
+// NumberLiteral is an integer literal, e.g. `42`.
+type NumberLiteral struct {
+	Token token.Token
+	Value int64
+
+	Trivia
+}
+
+func (n *NumberLiteral) expressionNode()      {}
+func (n *NumberLiteral) TokenLiteral() string { return n.Token.Lexeme }
+
+// BinaryExpr is a binary operator expression, e.g. `x - 5`.
+type BinaryExpr struct {
+	Token    token.Token // the operator token, e.g. MINUS
+	Left     Expression
+	Operator string
+	Right    Expression
+
+	Trivia
+}
+
+func (b *BinaryExpr) expressionNode()      {}
+func (b *BinaryExpr) TokenLiteral() string { return b.Token.Lexeme }
+
+// UnaryExpr is a prefix operator expression, e.g. `-5`.
+type UnaryExpr struct {
+	Token    token.Token // the operator token, e.g. MINUS
+	Operator string
+	Right    Expression
+
+	Trivia
+}
+
+func (u *UnaryExpr) expressionNode()      {}
+func (u *UnaryExpr) TokenLiteral() string { return u.Token.Lexeme }
+
+// VarDecl declares and initializes a new variable, e.g. `var x = 1`.
+type VarDecl struct {
+	Token token.Token // the VAR token
+	Name  *Identifier
+	Value Expression
+
+	Trivia
+}
+
+func (v *VarDecl) statementNode()       {}
+func (v *VarDecl) TokenLiteral() string { return v.Token.Lexeme }
+
+// AssignStmt assigns a new value to an existing variable, e.g. `x = 1`.
+type AssignStmt struct {
+	Token token.Token // the ASSIGN token
+	Name  *Identifier
+	Value Expression
+
+	Trivia
+}
+
+func (a *AssignStmt) statementNode()       {}
+func (a *AssignStmt) TokenLiteral() string { return a.Token.Lexeme }
+
+// ExpressionStmt wraps an expression so it can appear in a statement list.
+type ExpressionStmt struct {
+	Token      token.Token // the first token of the expression
+	Expression Expression
+
+	Trivia
+}
+
+func (e *ExpressionStmt) statementNode()       {}
+func (e *ExpressionStmt) TokenLiteral() string { return e.Token.Lexeme }
+
+// Program is the root node of every parsed source file.
+type Program struct {
+	Statements []Statement
+
+	Trivia
+}
+
+func (p *Program) TokenLiteral() string {
+	if len(p.Statements) == 0 {
+		return ""
+	}
+	return p.Statements[0].TokenLiteral()
+}
@@ -0,0 +1,405 @@
+// Package astpb is a hand-written protobuf-wire-compatible encoding for the
+// AST. There is no protoc toolchain in this build environment, so these
+// message types and their Marshal/Unmarshal methods are written by hand
+// against the wire helpers in wire.go rather than generated; message shapes
+// must be kept in sync by hand with ast.proto.
+package astpb
+
+// Token mirrors token.Token so a serialized AST can still be mapped back to
+// source positions by downstream tooling (formatter, linter).
+type Token struct {
+	Type   int32
+	Lexeme string
+	Line   int32
+	Pos    int32
+}
+
+func (t *Token) Marshal() []byte {
+	if t == nil {
+		return nil
+	}
+	var buf []byte
+	buf = appendInt32Field(buf, 1, t.Type)
+	buf = appendStringField(buf, 2, t.Lexeme)
+	buf = appendInt32Field(buf, 3, t.Line)
+	buf = appendInt32Field(buf, 4, t.Pos)
+	return buf
+}
+
+func UnmarshalToken(data []byte) (*Token, error) {
+	fields, err := readFields(data)
+	if err != nil {
+		return nil, err
+	}
+	t := &Token{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			t.Type = int32(f.vint)
+		case 2:
+			t.Lexeme = string(f.buf)
+		case 3:
+			t.Line = int32(f.vint)
+		case 4:
+			t.Pos = int32(f.vint)
+		}
+	}
+	return t, nil
+}
+
+// NumberLiteral is the wire form of ast.NumberLiteral.
+type NumberLiteral struct {
+	Token *Token
+	Value int64
+}
+
+func (n *NumberLiteral) Marshal() []byte {
+	var buf []byte
+	buf = appendMessageField(buf, 1, n.Token.Marshal())
+	buf = appendInt64Field(buf, 2, n.Value)
+	return buf
+}
+
+func UnmarshalNumberLiteral(data []byte) (*NumberLiteral, error) {
+	fields, err := readFields(data)
+	if err != nil {
+		return nil, err
+	}
+	n := &NumberLiteral{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			n.Token, err = UnmarshalToken(f.buf)
+		case 2:
+			n.Value = int64(f.vint)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// Identifier is the wire form of ast.Identifier.
+type Identifier struct {
+	Token *Token
+	Name  string
+}
+
+func (i *Identifier) Marshal() []byte {
+	var buf []byte
+	buf = appendMessageField(buf, 1, i.Token.Marshal())
+	buf = appendStringField(buf, 2, i.Name)
+	return buf
+}
+
+func UnmarshalIdentifier(data []byte) (*Identifier, error) {
+	fields, err := readFields(data)
+	if err != nil {
+		return nil, err
+	}
+	i := &Identifier{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			i.Token, err = UnmarshalToken(f.buf)
+		case 2:
+			i.Name = string(f.buf)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+// BinaryExpr is the wire form of ast.BinaryExpr.
+type BinaryExpr struct {
+	Token    *Token
+	Left     *Node
+	Operator string
+	Right    *Node
+}
+
+func (b *BinaryExpr) Marshal() []byte {
+	var buf []byte
+	buf = appendMessageField(buf, 1, b.Token.Marshal())
+	buf = appendMessageField(buf, 2, b.Left.Marshal())
+	buf = appendStringField(buf, 3, b.Operator)
+	buf = appendMessageField(buf, 4, b.Right.Marshal())
+	return buf
+}
+
+func UnmarshalBinaryExpr(data []byte) (*BinaryExpr, error) {
+	fields, err := readFields(data)
+	if err != nil {
+		return nil, err
+	}
+	b := &BinaryExpr{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			b.Token, err = UnmarshalToken(f.buf)
+		case 2:
+			b.Left, err = UnmarshalNode(f.buf)
+		case 3:
+			b.Operator = string(f.buf)
+		case 4:
+			b.Right, err = UnmarshalNode(f.buf)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// UnaryExpr is the wire form of ast.UnaryExpr.
+type UnaryExpr struct {
+	Token    *Token
+	Operator string
+	Right    *Node
+}
+
+func (u *UnaryExpr) Marshal() []byte {
+	var buf []byte
+	buf = appendMessageField(buf, 1, u.Token.Marshal())
+	buf = appendStringField(buf, 2, u.Operator)
+	buf = appendMessageField(buf, 3, u.Right.Marshal())
+	return buf
+}
+
+func UnmarshalUnaryExpr(data []byte) (*UnaryExpr, error) {
+	fields, err := readFields(data)
+	if err != nil {
+		return nil, err
+	}
+	u := &UnaryExpr{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			u.Token, err = UnmarshalToken(f.buf)
+		case 2:
+			u.Operator = string(f.buf)
+		case 3:
+			u.Right, err = UnmarshalNode(f.buf)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+// VarDecl is the wire form of ast.VarDecl.
+type VarDecl struct {
+	Token *Token
+	Name  *Identifier
+	Value *Node
+}
+
+func (v *VarDecl) Marshal() []byte {
+	var buf []byte
+	buf = appendMessageField(buf, 1, v.Token.Marshal())
+	buf = appendMessageField(buf, 2, v.Name.Marshal())
+	buf = appendMessageField(buf, 3, v.Value.Marshal())
+	return buf
+}
+
+func UnmarshalVarDecl(data []byte) (*VarDecl, error) {
+	fields, err := readFields(data)
+	if err != nil {
+		return nil, err
+	}
+	v := &VarDecl{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			v.Token, err = UnmarshalToken(f.buf)
+		case 2:
+			v.Name, err = UnmarshalIdentifier(f.buf)
+		case 3:
+			v.Value, err = UnmarshalNode(f.buf)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// AssignStmt is the wire form of ast.AssignStmt.
+type AssignStmt struct {
+	Token *Token
+	Name  *Identifier
+	Value *Node
+}
+
+func (a *AssignStmt) Marshal() []byte {
+	var buf []byte
+	buf = appendMessageField(buf, 1, a.Token.Marshal())
+	buf = appendMessageField(buf, 2, a.Name.Marshal())
+	buf = appendMessageField(buf, 3, a.Value.Marshal())
+	return buf
+}
+
+func UnmarshalAssignStmt(data []byte) (*AssignStmt, error) {
+	fields, err := readFields(data)
+	if err != nil {
+		return nil, err
+	}
+	a := &AssignStmt{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			a.Token, err = UnmarshalToken(f.buf)
+		case 2:
+			a.Name, err = UnmarshalIdentifier(f.buf)
+		case 3:
+			a.Value, err = UnmarshalNode(f.buf)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// ExpressionStmt is the wire form of ast.ExpressionStmt.
+type ExpressionStmt struct {
+	Token      *Token
+	Expression *Node
+}
+
+func (e *ExpressionStmt) Marshal() []byte {
+	var buf []byte
+	buf = appendMessageField(buf, 1, e.Token.Marshal())
+	buf = appendMessageField(buf, 2, e.Expression.Marshal())
+	return buf
+}
+
+func UnmarshalExpressionStmt(data []byte) (*ExpressionStmt, error) {
+	fields, err := readFields(data)
+	if err != nil {
+		return nil, err
+	}
+	e := &ExpressionStmt{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			e.Token, err = UnmarshalToken(f.buf)
+		case 2:
+			e.Expression, err = UnmarshalNode(f.buf)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Program is the wire form of ast.Program.
+type Program struct {
+	Statements []*Node
+}
+
+func (p *Program) Marshal() []byte {
+	// Non-nil even with no statements, so Node.Marshal still writes the
+	// (zero-length) program field and an empty Program survives a round trip.
+	buf := []byte{}
+	for _, s := range p.Statements {
+		buf = appendMessageField(buf, 1, s.Marshal())
+	}
+	return buf
+}
+
+func UnmarshalProgram(data []byte) (*Program, error) {
+	fields, err := readFields(data)
+	if err != nil {
+		return nil, err
+	}
+	p := &Program{}
+	for _, f := range fields {
+		if f.num != 1 {
+			continue
+		}
+		n, err := UnmarshalNode(f.buf)
+		if err != nil {
+			return nil, err
+		}
+		p.Statements = append(p.Statements, n)
+	}
+	return p, nil
+}
+
+// Node wraps every expression/statement variant in a single oneof so a tree
+// of heterogeneous nodes can be serialized as one message graph. Exactly one
+// field is set, matching proto3 oneof semantics.
+type Node struct {
+	NumberLiteral  *NumberLiteral
+	Identifier     *Identifier
+	BinaryExpr     *BinaryExpr
+	UnaryExpr      *UnaryExpr
+	VarDecl        *VarDecl
+	AssignStmt     *AssignStmt
+	ExpressionStmt *ExpressionStmt
+	Program        *Program
+}
+
+func (n *Node) Marshal() []byte {
+	if n == nil {
+		return nil
+	}
+	var buf []byte
+	switch {
+	case n.NumberLiteral != nil:
+		buf = appendMessageField(buf, 1, n.NumberLiteral.Marshal())
+	case n.Identifier != nil:
+		buf = appendMessageField(buf, 2, n.Identifier.Marshal())
+	case n.BinaryExpr != nil:
+		buf = appendMessageField(buf, 3, n.BinaryExpr.Marshal())
+	case n.UnaryExpr != nil:
+		buf = appendMessageField(buf, 4, n.UnaryExpr.Marshal())
+	case n.VarDecl != nil:
+		buf = appendMessageField(buf, 5, n.VarDecl.Marshal())
+	case n.AssignStmt != nil:
+		buf = appendMessageField(buf, 6, n.AssignStmt.Marshal())
+	case n.ExpressionStmt != nil:
+		buf = appendMessageField(buf, 7, n.ExpressionStmt.Marshal())
+	case n.Program != nil:
+		buf = appendMessageField(buf, 8, n.Program.Marshal())
+	}
+	return buf
+}
+
+func UnmarshalNode(data []byte) (*Node, error) {
+	fields, err := readFields(data)
+	if err != nil {
+		return nil, err
+	}
+	n := &Node{}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			n.NumberLiteral, err = UnmarshalNumberLiteral(f.buf)
+		case 2:
+			n.Identifier, err = UnmarshalIdentifier(f.buf)
+		case 3:
+			n.BinaryExpr, err = UnmarshalBinaryExpr(f.buf)
+		case 4:
+			n.UnaryExpr, err = UnmarshalUnaryExpr(f.buf)
+		case 5:
+			n.VarDecl, err = UnmarshalVarDecl(f.buf)
+		case 6:
+			n.AssignStmt, err = UnmarshalAssignStmt(f.buf)
+		case 7:
+			n.ExpressionStmt, err = UnmarshalExpressionStmt(f.buf)
+		case 8:
+			n.Program, err = UnmarshalProgram(f.buf)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
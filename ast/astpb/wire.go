@@ -0,0 +1,107 @@
+// This file implements the varint/length-delimited wire encoding used by the
+// hand-written message types in ast.pb.go. It intentionally does not use
+// google.golang.org/protobuf: real protoc-gen-go output exposes a different
+// API (ProtoReflect, Reset, String) than the bare Marshal/Unmarshal methods
+// that package ast's serialize.go calls, so the two are not interchangeable.
+package astpb
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+func appendTag(buf []byte, field int, wireType int) []byte {
+	return appendVarint(buf, uint64(field)<<3|uint64(wireType))
+}
+
+func appendVarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
+
+func appendInt32Field(buf []byte, field int, v int32) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = appendTag(buf, field, wireVarint)
+	return appendVarint(buf, uint64(v))
+}
+
+func appendInt64Field(buf []byte, field int, v int64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = appendTag(buf, field, wireVarint)
+	return appendVarint(buf, uint64(v))
+}
+
+func appendStringField(buf []byte, field int, v string) []byte {
+	if v == "" {
+		return buf
+	}
+	buf = appendTag(buf, field, wireBytes)
+	buf = appendVarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
+
+func appendMessageField(buf []byte, field int, payload []byte) []byte {
+	if payload == nil {
+		return buf
+	}
+	buf = appendTag(buf, field, wireBytes)
+	buf = appendVarint(buf, uint64(len(payload)))
+	return append(buf, payload...)
+}
+
+// field is a single decoded (field number, wire type, value) triple read off
+// the wire by readFields.
+type field struct {
+	num  int
+	wire int
+	vint uint64
+	buf  []byte
+}
+
+func readFields(data []byte) ([]field, error) {
+	var fields []field
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("astpb: malformed tag")
+		}
+		data = data[n:]
+		f := field{num: int(tag >> 3), wire: int(tag & 0x7)}
+
+		switch f.wire {
+		case wireVarint:
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return nil, fmt.Errorf("astpb: malformed varint")
+			}
+			f.vint = v
+			data = data[n:]
+		case wireBytes:
+			l, n := binary.Uvarint(data)
+			if n <= 0 {
+				return nil, fmt.Errorf("astpb: malformed length")
+			}
+			data = data[n:]
+			if uint64(len(data)) < l {
+				return nil, fmt.Errorf("astpb: truncated message")
+			}
+			f.buf = data[:l]
+			data = data[l:]
+		default:
+			return nil, fmt.Errorf("astpb: unsupported wire type %d", f.wire)
+		}
+
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
@@ -0,0 +1,219 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/vomolo/interpreter/ast/astpb"
+	"github.com/vomolo/interpreter/token"
+)
+
+// Marshal serializes node to its protobuf wire representation so it can be
+// cached to disk and parsed only once.
+func Marshal(node Node) ([]byte, error) {
+	n, err := toProto(node)
+	if err != nil {
+		return nil, err
+	}
+	return n.Marshal(), nil
+}
+
+// Unmarshal deserializes data produced by Marshal back into a Node. The
+// result is interchangeable with a freshly parsed tree: Eval does not care
+// which one produced it.
+func Unmarshal(data []byte) (Node, error) {
+	n, err := astpb.UnmarshalNode(data)
+	if err != nil {
+		return nil, err
+	}
+	return fromProto(n)
+}
+
+func toProtoToken(t token.Token) *astpb.Token {
+	return &astpb.Token{Type: int32(t.Type), Lexeme: t.Lexeme, Line: int32(t.Line), Pos: int32(t.StartOffset)}
+}
+
+func fromProtoToken(t *astpb.Token) token.Token {
+	if t == nil {
+		return token.Token{}
+	}
+	return token.Token{Type: token.Type(t.Type), Lexeme: t.Lexeme, Line: int(t.Line), StartOffset: int(t.Pos)}
+}
+
+func toProto(node Node) (*astpb.Node, error) {
+	switch n := node.(type) {
+	case *Program:
+		stmts := make([]*astpb.Node, len(n.Statements))
+		for i, s := range n.Statements {
+			pn, err := toProto(s)
+			if err != nil {
+				return nil, err
+			}
+			stmts[i] = pn
+		}
+		return &astpb.Node{Program: &astpb.Program{Statements: stmts}}, nil
+
+	case *NumberLiteral:
+		return &astpb.Node{NumberLiteral: &astpb.NumberLiteral{Token: toProtoToken(n.Token), Value: n.Value}}, nil
+
+	case *Identifier:
+		return &astpb.Node{Identifier: &astpb.Identifier{Token: toProtoToken(n.Token), Name: n.Name}}, nil
+
+	case *BinaryExpr:
+		left, err := toProto(n.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := toProto(n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return &astpb.Node{BinaryExpr: &astpb.BinaryExpr{Token: toProtoToken(n.Token), Left: left, Operator: n.Operator, Right: right}}, nil
+
+	case *UnaryExpr:
+		right, err := toProto(n.Right)
+		if err != nil {
+			return nil, err
+		}
+		return &astpb.Node{UnaryExpr: &astpb.UnaryExpr{Token: toProtoToken(n.Token), Operator: n.Operator, Right: right}}, nil
+
+	case *VarDecl:
+		name, err := toProto(n.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := toProto(n.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &astpb.Node{VarDecl: &astpb.VarDecl{Token: toProtoToken(n.Token), Name: name.Identifier, Value: value}}, nil
+
+	case *AssignStmt:
+		name, err := toProto(n.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := toProto(n.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &astpb.Node{AssignStmt: &astpb.AssignStmt{Token: toProtoToken(n.Token), Name: name.Identifier, Value: value}}, nil
+
+	case *ExpressionStmt:
+		expr, err := toProto(n.Expression)
+		if err != nil {
+			return nil, err
+		}
+		return &astpb.Node{ExpressionStmt: &astpb.ExpressionStmt{Token: toProtoToken(n.Token), Expression: expr}}, nil
+
+	default:
+		return nil, fmt.Errorf("ast: unsupported node type %T", node)
+	}
+}
+
+func fromProto(n *astpb.Node) (Node, error) {
+	if n == nil {
+		return nil, fmt.Errorf("ast: missing node")
+	}
+	switch {
+	case n.Program != nil:
+		stmts := make([]Statement, len(n.Program.Statements))
+		for i, s := range n.Program.Statements {
+			node, err := fromProto(s)
+			if err != nil {
+				return nil, err
+			}
+			stmt, ok := node.(Statement)
+			if !ok {
+				return nil, fmt.Errorf("ast: node %T is not a statement", node)
+			}
+			stmts[i] = stmt
+		}
+		return &Program{Statements: stmts}, nil
+
+	case n.NumberLiteral != nil:
+		return &NumberLiteral{Token: fromProtoToken(n.NumberLiteral.Token), Value: n.NumberLiteral.Value}, nil
+
+	case n.Identifier != nil:
+		return &Identifier{Token: fromProtoToken(n.Identifier.Token), Name: n.Identifier.Name}, nil
+
+	case n.BinaryExpr != nil:
+		left, err := fromProto(n.BinaryExpr.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := fromProto(n.BinaryExpr.Right)
+		if err != nil {
+			return nil, err
+		}
+		leftExpr, ok := left.(Expression)
+		if !ok {
+			return nil, fmt.Errorf("ast: node %T is not an expression", left)
+		}
+		rightExpr, ok := right.(Expression)
+		if !ok {
+			return nil, fmt.Errorf("ast: node %T is not an expression", right)
+		}
+		return &BinaryExpr{Token: fromProtoToken(n.BinaryExpr.Token), Left: leftExpr, Operator: n.BinaryExpr.Operator, Right: rightExpr}, nil
+
+	case n.UnaryExpr != nil:
+		right, err := fromProto(n.UnaryExpr.Right)
+		if err != nil {
+			return nil, err
+		}
+		rightExpr, ok := right.(Expression)
+		if !ok {
+			return nil, fmt.Errorf("ast: node %T is not an expression", right)
+		}
+		return &UnaryExpr{Token: fromProtoToken(n.UnaryExpr.Token), Operator: n.UnaryExpr.Operator, Right: rightExpr}, nil
+
+	case n.VarDecl != nil:
+		if n.VarDecl.Name == nil {
+			return nil, fmt.Errorf("ast: VarDecl missing Name")
+		}
+		value, err := fromProto(n.VarDecl.Value)
+		if err != nil {
+			return nil, err
+		}
+		valueExpr, ok := value.(Expression)
+		if !ok {
+			return nil, fmt.Errorf("ast: node %T is not an expression", value)
+		}
+		return &VarDecl{
+			Token: fromProtoToken(n.VarDecl.Token),
+			Name:  &Identifier{Token: fromProtoToken(n.VarDecl.Name.Token), Name: n.VarDecl.Name.Name},
+			Value: valueExpr,
+		}, nil
+
+	case n.AssignStmt != nil:
+		if n.AssignStmt.Name == nil {
+			return nil, fmt.Errorf("ast: AssignStmt missing Name")
+		}
+		value, err := fromProto(n.AssignStmt.Value)
+		if err != nil {
+			return nil, err
+		}
+		valueExpr, ok := value.(Expression)
+		if !ok {
+			return nil, fmt.Errorf("ast: node %T is not an expression", value)
+		}
+		return &AssignStmt{
+			Token: fromProtoToken(n.AssignStmt.Token),
+			Name:  &Identifier{Token: fromProtoToken(n.AssignStmt.Name.Token), Name: n.AssignStmt.Name.Name},
+			Value: valueExpr,
+		}, nil
+
+	case n.ExpressionStmt != nil:
+		expr, err := fromProto(n.ExpressionStmt.Expression)
+		if err != nil {
+			return nil, err
+		}
+		exprExpr, ok := expr.(Expression)
+		if !ok {
+			return nil, fmt.Errorf("ast: node %T is not an expression", expr)
+		}
+		return &ExpressionStmt{Token: fromProtoToken(n.ExpressionStmt.Token), Expression: exprExpr}, nil
+
+	default:
+		return nil, fmt.Errorf("ast: empty node")
+	}
+}
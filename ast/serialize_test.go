@@ -0,0 +1,88 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/vomolo/interpreter/ast"
+	"github.com/vomolo/interpreter/ast/astpb"
+	"github.com/vomolo/interpreter/eval"
+	"github.com/vomolo/interpreter/lexer"
+	"github.com/vomolo/interpreter/parser"
+)
+
+func TestMarshalUnmarshalRoundTrip(t *testing.T) {
+	l := lexer.New("var x = 42 + 3 * (y - 5)")
+	p := parser.New(l)
+	program := p.ParseProgram()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("parser errors: %v", errs)
+	}
+
+	data, err := ast.Marshal(program)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	node, err := ast.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	env := eval.NewEnvironment()
+	env.Set("y", int64(10))
+
+	result, err := eval.Eval(node, env)
+	if err != nil {
+		t.Fatalf("eval deserialized tree: %v", err)
+	}
+	if result != int64(57) {
+		t.Fatalf("expected 57, got %v", result)
+	}
+}
+
+func TestMarshalUnmarshalPreservesStartOffset(t *testing.T) {
+	l := lexer.New("var x = 1")
+	p := parser.New(l)
+	program := p.ParseProgram()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("parser errors: %v", errs)
+	}
+
+	decl, ok := program.Statements[0].(*ast.VarDecl)
+	if !ok {
+		t.Fatalf("expected *ast.VarDecl, got %T", program.Statements[0])
+	}
+	if decl.Name.Token.StartOffset == 0 {
+		t.Fatalf("expected parsed Identifier token to carry a nonzero StartOffset")
+	}
+
+	data, err := ast.Marshal(program)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	node, err := ast.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	roundTripped, ok := node.(*ast.Program).Statements[0].(*ast.VarDecl)
+	if !ok {
+		t.Fatalf("expected *ast.VarDecl after round trip, got %T", node.(*ast.Program).Statements[0])
+	}
+	if roundTripped.Name.Token.StartOffset != decl.Name.Token.StartOffset {
+		t.Fatalf("expected StartOffset %d to round-trip, got %d", decl.Name.Token.StartOffset, roundTripped.Name.Token.StartOffset)
+	}
+}
+
+// TestUnmarshalMissingSubmessageReturnsError guards against a panic when a
+// wire node is missing a field that fromProto assumed would be present,
+// e.g. a truncated or hand-rolled cache file. It must report a decode error
+// instead of crashing the process.
+func TestUnmarshalMissingSubmessageReturnsError(t *testing.T) {
+	data := (&astpb.Node{VarDecl: &astpb.VarDecl{Name: &astpb.Identifier{Name: "x"}}}).Marshal()
+
+	if _, err := ast.Unmarshal(data); err == nil {
+		t.Fatalf("expected an error for a VarDecl missing its Value, got nil")
+	}
+}
@@ -0,0 +1,236 @@
+// Command repl is an interactive read-eval-print loop for the interpreter,
+// in the spirit of the Monkey language's REPL: type an expression, see it
+// evaluated. It additionally understands a handful of meta-commands for
+// inspecting what the lexer/parser produced.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/vomolo/interpreter/ast"
+	"github.com/vomolo/interpreter/eval"
+	"github.com/vomolo/interpreter/lexer"
+	"github.com/vomolo/interpreter/parser"
+	"github.com/vomolo/interpreter/token"
+)
+
+const prompt = ">> "
+
+// mode selects what the next evaluated line does instead of printing a
+// result, set by a one-shot meta-command such as :tokens or :ast.
+type mode int
+
+const (
+	modeEval mode = iota
+	modeTokens
+	modeAST
+)
+
+func main() {
+	if err := run(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Split(splitStatements)
+
+	env := eval.NewEnvironment()
+	mode := modeEval
+
+	fmt.Fprint(out, prompt)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			fmt.Fprint(out, prompt)
+			continue
+		case line == ":quit":
+			return nil
+		case line == ":env":
+			printEnv(out, env)
+			fmt.Fprint(out, prompt)
+			continue
+		case line == ":tokens":
+			mode = modeTokens
+			fmt.Fprint(out, prompt)
+			continue
+		case line == ":ast":
+			mode = modeAST
+			fmt.Fprint(out, prompt)
+			continue
+		case strings.HasPrefix(line, ":load "):
+			loadFile(out, strings.TrimSpace(strings.TrimPrefix(line, ":load ")), env)
+			fmt.Fprint(out, prompt)
+			continue
+		}
+
+		switch mode {
+		case modeTokens:
+			printTokens(out, line)
+		case modeAST:
+			printProgram(out, line)
+		default:
+			evalLine(out, line, env)
+		}
+		mode = modeEval
+
+		fmt.Fprint(out, prompt)
+	}
+
+	return scanner.Err()
+}
+
+func evalLine(out io.Writer, line string, env *eval.Environment) {
+	program, errs := parseLine(line)
+	if len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintln(out, e)
+		}
+		return
+	}
+
+	result, err := eval.Eval(program, env)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return
+	}
+	fmt.Fprintf(out, "%v\n", result)
+}
+
+func printTokens(out io.Writer, line string) {
+	l := lexer.New(line)
+	defer l.Close()
+	for {
+		tok := l.NextToken()
+		fmt.Fprintf(out, "%+v\n", tok)
+		if tok.Type == token.EOF || tok.Type == token.ERROR {
+			return
+		}
+	}
+}
+
+func printProgram(out io.Writer, line string) {
+	program, errs := parseLine(line)
+	if len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintln(out, e)
+		}
+		return
+	}
+	for _, stmt := range program.Statements {
+		printNode(out, stmt, 0)
+	}
+}
+
+func printEnv(out io.Writer, env *eval.Environment) {
+	for name, value := range env.All() {
+		fmt.Fprintf(out, "%s = %v\n", name, value)
+	}
+}
+
+func loadFile(out io.Writer, path string, env *eval.Environment) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return
+	}
+	evalLine(out, string(data), env)
+}
+
+func parseLine(line string) (*ast.Program, []string) {
+	l := lexer.New(line)
+	defer l.Close()
+	p := parser.New(l)
+	program := p.ParseProgram()
+	return program, p.Errors()
+}
+
+// printNode pretty-prints an AST node, indenting child nodes so the tree
+// shape is visible.
+func printNode(out io.Writer, node ast.Node, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	switch n := node.(type) {
+	case *ast.VarDecl:
+		fmt.Fprintf(out, "%sVarDecl %s\n", indent, n.Name.Name)
+		printNode(out, n.Value, depth+1)
+	case *ast.AssignStmt:
+		fmt.Fprintf(out, "%sAssignStmt %s\n", indent, n.Name.Name)
+		printNode(out, n.Value, depth+1)
+	case *ast.ExpressionStmt:
+		printNode(out, n.Expression, depth)
+	case *ast.BinaryExpr:
+		fmt.Fprintf(out, "%sBinaryExpr %q\n", indent, n.Operator)
+		printNode(out, n.Left, depth+1)
+		printNode(out, n.Right, depth+1)
+	case *ast.UnaryExpr:
+		fmt.Fprintf(out, "%sUnaryExpr %q\n", indent, n.Operator)
+		printNode(out, n.Right, depth+1)
+	case *ast.NumberLiteral:
+		fmt.Fprintf(out, "%sNumberLiteral %d\n", indent, n.Value)
+	case *ast.Identifier:
+		fmt.Fprintf(out, "%sIdentifier %s\n", indent, n.Name)
+	default:
+		fmt.Fprintf(out, "%s%T\n", indent, n)
+	}
+}
+
+// splitStatements is a bufio.SplitFunc that accumulates lines until the
+// parens seen so far balance out, so pasting a multi-line expression like
+//
+//	var x = (1 +
+//	  2)
+//
+// is treated as a single statement instead of failing to parse line by line.
+func splitStatements(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	searched := 0
+	for {
+		i := bytes.IndexByte(data[searched:], '\n')
+		if i < 0 {
+			break
+		}
+		end := searched + i
+		if parenDepth(data[:end]) <= 0 {
+			return end + 1, data[:end], nil
+		}
+		searched = end + 1
+	}
+
+	if atEOF {
+		if len(data) == 0 {
+			return 0, nil, nil
+		}
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+// parenDepth returns the number of LPAREN tokens in data not yet closed by
+// a matching RPAREN.
+func parenDepth(data []byte) int {
+	l := lexer.New(string(data))
+	defer l.Close()
+	depth := 0
+	for {
+		tok := l.NextToken()
+		if tok.Type == token.EOF || tok.Type == token.ERROR {
+			return depth
+		}
+		switch tok.Type {
+		case token.LPAREN:
+			depth++
+		case token.RPAREN:
+			depth--
+		}
+	}
+}
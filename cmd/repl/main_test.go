@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunEvaluatesExpression(t *testing.T) {
+	in := strings.NewReader("var x = 1 + 2\n:quit\n")
+	var out bytes.Buffer
+
+	if err := run(in, &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !strings.Contains(out.String(), "3") {
+		t.Fatalf("expected output to contain evaluated result 3, got %q", out.String())
+	}
+}
+
+func TestRunMultilineParens(t *testing.T) {
+	in := strings.NewReader("var x = (1 +\n2)\n:quit\n")
+	var out bytes.Buffer
+
+	if err := run(in, &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !strings.Contains(out.String(), "3") {
+		t.Fatalf("expected multi-line paren input to evaluate to 3, got %q", out.String())
+	}
+}
+
+func TestRunTokensMetaCommand(t *testing.T) {
+	in := strings.NewReader(":tokens\n1 + 2\n:quit\n")
+	var out bytes.Buffer
+
+	if err := run(in, &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !strings.Contains(out.String(), "NUMBER") {
+		t.Fatalf("expected token dump to mention NUMBER, got %q", out.String())
+	}
+}
+
+func TestParenDepth(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"(1 + 2)", 0},
+		{"(1 + (2", 2},
+		{"1 + 2", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parenDepth([]byte(tt.input)); got != tt.want {
+			t.Fatalf("parenDepth(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
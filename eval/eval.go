@@ -0,0 +1,146 @@
+// Package eval walks an AST and evaluates it against an Environment.
+package eval
+
+import (
+	"fmt"
+
+	"github.com/vomolo/interpreter/ast"
+)
+
+// Value is the runtime value produced by evaluating an expression.
+type Value interface{}
+
+// Environment binds names to values.
+type Environment struct {
+	vars map[string]Value
+}
+
+// NewEnvironment creates an empty Environment.
+func NewEnvironment() *Environment {
+	return &Environment{vars: make(map[string]Value)}
+}
+
+// Get looks up name, reporting whether it was bound.
+func (e *Environment) Get(name string) (Value, bool) {
+	v, ok := e.vars[name]
+	return v, ok
+}
+
+// Set binds name to value.
+func (e *Environment) Set(name string, value Value) {
+	e.vars[name] = value
+}
+
+// All returns a snapshot of every binding currently in the environment.
+func (e *Environment) All() map[string]Value {
+	out := make(map[string]Value, len(e.vars))
+	for name, value := range e.vars {
+		out[name] = value
+	}
+	return out
+}
+
+// Eval evaluates a single AST node against env.
+func Eval(node ast.Node, env *Environment) (Value, error) {
+	switch n := node.(type) {
+	case *ast.Program:
+		var result Value
+		for _, stmt := range n.Statements {
+			v, err := Eval(stmt, env)
+			if err != nil {
+				return nil, err
+			}
+			result = v
+		}
+		return result, nil
+
+	case *ast.VarDecl:
+		value, err := Eval(n.Value, env)
+		if err != nil {
+			return nil, err
+		}
+		env.Set(n.Name.Name, value)
+		return value, nil
+
+	case *ast.AssignStmt:
+		if _, ok := env.Get(n.Name.Name); !ok {
+			return nil, fmt.Errorf("undefined variable: %s", n.Name.Name)
+		}
+		value, err := Eval(n.Value, env)
+		if err != nil {
+			return nil, err
+		}
+		env.Set(n.Name.Name, value)
+		return value, nil
+
+	case *ast.ExpressionStmt:
+		return Eval(n.Expression, env)
+
+	case *ast.NumberLiteral:
+		return n.Value, nil
+
+	case *ast.Identifier:
+		value, ok := env.Get(n.Name)
+		if !ok {
+			return nil, fmt.Errorf("undefined variable: %s", n.Name)
+		}
+		return value, nil
+
+	case *ast.UnaryExpr:
+		right, err := Eval(n.Right, env)
+		if err != nil {
+			return nil, err
+		}
+		return evalUnaryExpr(n.Operator, right)
+
+	case *ast.BinaryExpr:
+		left, err := Eval(n.Left, env)
+		if err != nil {
+			return nil, err
+		}
+		right, err := Eval(n.Right, env)
+		if err != nil {
+			return nil, err
+		}
+		return evalBinaryExpr(n.Operator, left, right)
+	}
+
+	return nil, fmt.Errorf("unsupported node type: %T", node)
+}
+
+func evalUnaryExpr(operator string, right Value) (Value, error) {
+	r, ok := right.(int64)
+	if !ok {
+		return nil, fmt.Errorf("unary %s: unsupported operand %v", operator, right)
+	}
+	switch operator {
+	case "-":
+		return -r, nil
+	default:
+		return nil, fmt.Errorf("unknown unary operator: %s", operator)
+	}
+}
+
+func evalBinaryExpr(operator string, left, right Value) (Value, error) {
+	l, lok := left.(int64)
+	r, rok := right.(int64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("%s: unsupported operands %v, %v", operator, left, right)
+	}
+
+	switch operator {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	default:
+		return nil, fmt.Errorf("unknown operator: %s", operator)
+	}
+}
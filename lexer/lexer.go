@@ -0,0 +1,479 @@
+// Package lexer implements the lexical analyzer for the interpreter.
+//
+// It follows the state-function design from Rob Pike's "Lexical Scanning in
+// Go" talk (see also text/template/parse/lex.go): each stateFn scans one
+// token's worth of input and returns the stateFn that should run next,
+// sending completed tokens over a channel. This avoids deeply nested
+// switches once multi-character operators are involved.
+package lexer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/vomolo/interpreter/token"
+)
+
+// eof is returned by next when the input is exhausted.
+const eof = rune(0)
+
+// bom is the UTF-8 byte order mark, silently dropped if it leads the input.
+const bom = '\uFEFF'
+
+// stateFn represents the state of the scanner as a function that returns
+// the next state.
+type stateFn func(*Lexer) stateFn
+
+// Lexer represents a lexical analyzer.
+type Lexer struct {
+	input      string
+	start, pos int
+	width      int // width in bytes of the last rune read by next, for backup
+
+	line, col         int // position of the rune at pos (1-based)
+	lastLine, lastCol int // position before the last next(), for backup
+
+	tokStartLine, tokStartCol int // position of the token currently being scanned
+
+	preserveTrivia bool // see PreserveTrivia
+
+	startOnce sync.Once
+	tokens    chan token.Token
+}
+
+// New initializes a new lexer; tokens are retrieved with NextToken. A
+// leading UTF-8 BOM, if present, is stripped. Scanning does not begin until
+// the first call to NextToken, so PreserveTrivia is safe to call beforehand.
+func New(input string) *Lexer {
+	if r, size := utf8.DecodeRuneInString(input); r == bom {
+		input = input[size:]
+	}
+	return &Lexer{
+		input:        input,
+		line:         1,
+		col:          1,
+		tokStartLine: 1,
+		tokStartCol:  1,
+		tokens:       make(chan token.Token, 2),
+	}
+}
+
+// PreserveTrivia controls whether whitespace and comments are emitted as
+// WHITESPACE/COMMENT tokens instead of being skipped. It must be called
+// before the first call to NextToken. Trivia-aware scanning is how a future
+// formatter/linter can round-trip source exactly.
+func (l *Lexer) PreserveTrivia(enabled bool) {
+	l.preserveTrivia = enabled
+}
+
+// run lexes the input by repeatedly invoking the current state function
+// until nil is returned, then closes the token channel.
+func (l *Lexer) run() {
+	for state := lexDefault; state != nil; {
+		state = state(l)
+	}
+	close(l.tokens)
+}
+
+// NextToken returns the next token from the input.
+func (l *Lexer) NextToken() token.Token {
+	l.startOnce.Do(func() { go l.run() })
+
+	tok, ok := <-l.tokens
+	if !ok {
+		return token.Token{Type: token.EOF, Line: l.line, Column: l.col}
+	}
+	return tok
+}
+
+// Close drains any tokens the caller hasn't read so the background
+// goroutine started by NextToken can finish and its channel close, as in
+// text/template/parse/lex.go's drain. Callers that stop reading before an
+// EOF or ERROR token must call Close to avoid leaking that goroutine; it is
+// safe to call even if NextToken was never invoked, and safe to call more
+// than once.
+func (l *Lexer) Close() {
+	l.startOnce.Do(func() { go l.run() })
+	for range l.tokens {
+	}
+}
+
+// next decodes and returns the next rune in the input, advancing the
+// scanner. Multi-byte UTF-8 sequences are decoded whole, so identifiers
+// containing runes like π, naïve, or 変数 are not corrupted.
+func (l *Lexer) next() rune {
+	l.lastLine, l.lastCol = l.line, l.col
+
+	if l.pos >= len(l.input) {
+		l.width = 0
+		return eof
+	}
+	r, width := utf8.DecodeRuneInString(l.input[l.pos:])
+	l.width = width
+	l.pos += width
+
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+// backup steps back one rune. Can only be called once per call of next.
+func (l *Lexer) backup() {
+	l.pos -= l.width
+	l.line, l.col = l.lastLine, l.lastCol
+}
+
+// peek returns the next rune without consuming it.
+func (l *Lexer) peek() rune {
+	r := l.next()
+	l.backup()
+	return r
+}
+
+// ignore skips over the pending input before this point.
+func (l *Lexer) ignore() {
+	l.start = l.pos
+	l.tokStartLine, l.tokStartCol = l.line, l.col
+}
+
+// accept consumes the next rune if it is in valid.
+func (l *Lexer) accept(valid string) bool {
+	if strings.ContainsRune(valid, l.next()) {
+		return true
+	}
+	l.backup()
+	return false
+}
+
+// acceptRun consumes a run of runes from valid.
+func (l *Lexer) acceptRun(valid string) {
+	for strings.ContainsRune(valid, l.next()) {
+	}
+	l.backup()
+}
+
+// emit passes a token of type t back to the client, built from the input
+// consumed since the last emit/ignore.
+func (l *Lexer) emit(t token.Type) {
+	l.emitLiteral(t, nil)
+}
+
+// emitLiteral is like emit but additionally attaches a decoded literal
+// value, e.g. the float64 behind a FLOAT token or the unescaped string
+// behind a STRING token.
+func (l *Lexer) emitLiteral(t token.Type, literal interface{}) {
+	l.tokens <- token.Token{
+		Type:        t,
+		Lexeme:      l.input[l.start:l.pos],
+		Literal:     literal,
+		Line:        l.tokStartLine,
+		Column:      l.tokStartCol,
+		StartOffset: l.start,
+		EndOffset:   l.pos,
+	}
+	l.start = l.pos
+	l.tokStartLine, l.tokStartCol = l.line, l.col
+}
+
+// errorf emits an ERROR token and terminates the scan by returning nil.
+func (l *Lexer) errorf(format string, args ...interface{}) stateFn {
+	l.tokens <- token.Token{
+		Type:        token.ERROR,
+		Lexeme:      fmt.Sprintf(format, args...),
+		Line:        l.line,
+		Column:      l.col,
+		StartOffset: l.start,
+		EndOffset:   l.pos,
+	}
+	return nil
+}
+
+// lexDefault is the top-level state: it skips whitespace and dispatches to
+// the state function responsible for whatever comes next.
+func lexDefault(l *Lexer) stateFn {
+	for {
+		switch r := l.next(); {
+		case r == eof:
+			l.emit(token.EOF)
+			return nil
+		case r == ' ' || r == '\t' || r == '\r' || r == '\n':
+			l.backup()
+			return lexWhitespace
+		case r == '/':
+			l.backup()
+			return lexSlashOrComment
+		case unicode.IsLetter(r):
+			l.backup()
+			return lexIdentifier
+		case unicode.IsDigit(r):
+			l.backup()
+			return lexNumber
+		case r == '"':
+			l.backup()
+			return lexString
+		case strings.ContainsRune("+-*();=<>!&|.", r):
+			l.backup()
+			return lexOperator
+		default:
+			return l.errorf("unexpected character %q at line %d", r, l.line)
+		}
+	}
+}
+
+// lexWhitespace scans a run of spaces, tabs, and newlines. It is ignored
+// unless PreserveTrivia is enabled, in which case it is emitted as a single
+// WHITESPACE token.
+func lexWhitespace(l *Lexer) stateFn {
+	for r := l.peek(); r == ' ' || r == '\t' || r == '\r' || r == '\n'; r = l.peek() {
+		l.next()
+	}
+	if l.preserveTrivia {
+		l.emit(token.WHITESPACE)
+	} else {
+		l.ignore()
+	}
+	return lexDefault
+}
+
+// lexSlashOrComment disambiguates a bare '/' (SLASH) from the start of a
+// line comment ('//') or a block comment ('/*').
+func lexSlashOrComment(l *Lexer) stateFn {
+	l.next() // consume '/'
+	switch l.peek() {
+	case '/':
+		return lexLineComment
+	case '*':
+		return lexBlockComment
+	default:
+		l.emit(token.SLASH)
+		return lexDefault
+	}
+}
+
+// lexLineComment scans a '//' comment through the end of the line.
+func lexLineComment(l *Lexer) stateFn {
+	l.next() // consume second '/'
+	for r := l.peek(); r != '\n' && r != eof; r = l.peek() {
+		l.next()
+	}
+	if l.preserveTrivia {
+		l.emit(token.COMMENT)
+	} else {
+		l.ignore()
+	}
+	return lexDefault
+}
+
+// lexBlockComment scans a '/* ... */' comment, allowing comments to nest.
+func lexBlockComment(l *Lexer) stateFn {
+	l.next() // consume '*'
+
+	for depth := 1; depth > 0; {
+		switch r := l.next(); r {
+		case eof:
+			return l.errorf("unterminated block comment at line %d", l.line)
+		case '/':
+			if l.accept("*") {
+				depth++
+			}
+		case '*':
+			if l.accept("/") {
+				depth--
+			}
+		}
+	}
+	if l.preserveTrivia {
+		l.emit(token.COMMENT)
+	} else {
+		l.ignore()
+	}
+	return lexDefault
+}
+
+// lexIdentifier scans an identifier or keyword.
+func lexIdentifier(l *Lexer) stateFn {
+	for unicode.IsLetter(l.peek()) || unicode.IsDigit(l.peek()) {
+		l.next()
+	}
+	lexeme := l.input[l.start:l.pos]
+	l.emit(token.LookupIdentifier(lexeme))
+	return lexDefault
+}
+
+// lexNumber scans an integer or float literal, including an optional
+// fractional part and e/E exponent.
+func lexNumber(l *Lexer) stateFn {
+	l.acceptRun("0123456789")
+
+	isFloat := false
+	if l.accept(".") {
+		isFloat = true
+		if !unicode.IsDigit(l.peek()) {
+			return l.errorf("malformed number: expected digit after '.' at line %d", l.line)
+		}
+		l.acceptRun("0123456789")
+		if l.peek() == '.' {
+			return l.errorf("malformed number: unexpected second '.' at line %d", l.line)
+		}
+	}
+
+	if l.accept("eE") {
+		isFloat = true
+		l.accept("+-")
+		if !unicode.IsDigit(l.peek()) {
+			return l.errorf("malformed number: expected digit in exponent at line %d", l.line)
+		}
+		l.acceptRun("0123456789")
+	}
+
+	lexeme := l.input[l.start:l.pos]
+	if isFloat {
+		value, err := strconv.ParseFloat(lexeme, 64)
+		if err != nil {
+			return l.errorf("malformed number %q at line %d", lexeme, l.line)
+		}
+		l.emitLiteral(token.FLOAT, value)
+	} else {
+		value, err := strconv.ParseInt(lexeme, 10, 64)
+		if err != nil {
+			return l.errorf("malformed number %q at line %d", lexeme, l.line)
+		}
+		l.emitLiteral(token.NUMBER, value)
+	}
+	return lexDefault
+}
+
+// lexString scans a double-quoted string literal, decoding escape sequences
+// into Token.Literal.
+func lexString(l *Lexer) stateFn {
+	l.next() // opening quote
+
+	var value strings.Builder
+	for {
+		switch r := l.next(); r {
+		case eof, '\n':
+			return l.errorf("unterminated string at line %d", l.line)
+		case '"':
+			l.emitLiteral(token.STRING, value.String())
+			return lexDefault
+		case '\\':
+			decoded, err := l.readEscape()
+			if err != nil {
+				return l.errorf("%s at line %d", err, l.line)
+			}
+			value.WriteRune(decoded)
+		default:
+			value.WriteRune(r)
+		}
+	}
+}
+
+// readEscape decodes the escape sequence following a backslash already
+// consumed by the caller: \n, \t, \", \\, and \uXXXX.
+func (l *Lexer) readEscape() (rune, error) {
+	switch r := l.next(); r {
+	case 'n':
+		return '\n', nil
+	case 't':
+		return '\t', nil
+	case '"':
+		return '"', nil
+	case '\\':
+		return '\\', nil
+	case 'u':
+		var code rune
+		for i := 0; i < 4; i++ {
+			d := l.next()
+			digit, ok := hexDigit(d)
+			if !ok {
+				return 0, fmt.Errorf("invalid \\u escape")
+			}
+			code = code<<4 | rune(digit)
+		}
+		return code, nil
+	default:
+		return 0, fmt.Errorf("invalid escape sequence \\%c", r)
+	}
+}
+
+func hexDigit(r rune) (int, bool) {
+	switch {
+	case r >= '0' && r <= '9':
+		return int(r - '0'), true
+	case r >= 'a' && r <= 'f':
+		return int(r-'a') + 10, true
+	case r >= 'A' && r <= 'F':
+		return int(r-'A') + 10, true
+	default:
+		return 0, false
+	}
+}
+
+// lexOperator scans a single- or multi-character operator or punctuation.
+func lexOperator(l *Lexer) stateFn {
+	switch r := l.next(); r {
+	case '+':
+		l.emit(token.PLUS)
+	case '-':
+		l.emit(token.MINUS)
+	case '*':
+		l.emit(token.ASTERISK)
+	case '(':
+		l.emit(token.LPAREN)
+	case ')':
+		l.emit(token.RPAREN)
+	case ';':
+		l.emit(token.SEMICOLON)
+	case '=':
+		if l.accept("=") {
+			l.emit(token.EQ)
+		} else {
+			l.emit(token.ASSIGN)
+		}
+	case '!':
+		if l.accept("=") {
+			l.emit(token.NEQ)
+		} else {
+			return l.errorf("unexpected character %q at line %d", r, l.line)
+		}
+	case '<':
+		if l.accept("=") {
+			l.emit(token.LTE)
+		} else {
+			l.emit(token.LT)
+		}
+	case '>':
+		if l.accept("=") {
+			l.emit(token.GTE)
+		} else {
+			l.emit(token.GT)
+		}
+	case '&':
+		if l.accept("&") {
+			l.emit(token.AND)
+		} else {
+			return l.errorf("unexpected character %q at line %d", r, l.line)
+		}
+	case '|':
+		if l.accept("|") {
+			l.emit(token.OR)
+		} else {
+			return l.errorf("unexpected character %q at line %d", r, l.line)
+		}
+	case '.':
+		if l.accept(".") && l.accept(".") {
+			l.emit(token.ELLIPSIS)
+		} else {
+			return l.errorf("unexpected character %q at line %d", r, l.line)
+		}
+	}
+	return lexDefault
+}
@@ -0,0 +1,158 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vomolo/interpreter/token"
+)
+
+func TestNextTokenMultiCharOperators(t *testing.T) {
+	input := `x == y; x != y; x <= y; x >= y; x < y; x > y; x && y; x || y; (x ...)`
+
+	want := []token.Type{
+		token.IDENTIFIER, token.EQ, token.IDENTIFIER, token.SEMICOLON,
+		token.IDENTIFIER, token.NEQ, token.IDENTIFIER, token.SEMICOLON,
+		token.IDENTIFIER, token.LTE, token.IDENTIFIER, token.SEMICOLON,
+		token.IDENTIFIER, token.GTE, token.IDENTIFIER, token.SEMICOLON,
+		token.IDENTIFIER, token.LT, token.IDENTIFIER, token.SEMICOLON,
+		token.IDENTIFIER, token.GT, token.IDENTIFIER, token.SEMICOLON,
+		token.IDENTIFIER, token.AND, token.IDENTIFIER, token.SEMICOLON,
+		token.IDENTIFIER, token.OR, token.IDENTIFIER, token.SEMICOLON,
+		token.LPAREN, token.IDENTIFIER, token.ELLIPSIS, token.RPAREN,
+	}
+
+	l := New(input)
+	for i, wantType := range want {
+		tok := l.NextToken()
+		if tok.Type != wantType {
+			t.Fatalf("token %d: want type %v, got %v (lexeme %q)", i, wantType, tok.Type, tok.Lexeme)
+		}
+	}
+}
+
+func TestNextTokenUnterminatedString(t *testing.T) {
+	l := New(`"unterminated`)
+	tok := l.NextToken()
+	if tok.Type != token.ERROR {
+		t.Fatalf("expected ERROR token, got %v", tok.Type)
+	}
+}
+
+func TestNextTokenUTF8Identifiers(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"π", "π"},
+		{"naïve", "naïve"},
+		{"変数1", "変数1"},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+		if tok.Type != token.IDENTIFIER {
+			t.Fatalf("input %q: expected IDENTIFIER, got %v", tt.input, tok.Type)
+		}
+		if tok.Lexeme != tt.want {
+			t.Fatalf("input %q: expected lexeme %q, got %q", tt.input, tt.want, tok.Lexeme)
+		}
+		if tok.EndOffset-tok.StartOffset != len(tt.input) {
+			t.Fatalf("input %q: expected byte span %d, got %d", tt.input, len(tt.input), tok.EndOffset-tok.StartOffset)
+		}
+	}
+}
+
+func TestNextTokenCombiningMark(t *testing.T) {
+	// "e\u0301" is a plain "e" followed by a combining acute accent (not
+	// the precomposed "\u00e9"); it must not corrupt the rest of the scan
+	// even though the combining mark is not itself a letter or digit.
+	input := "\"caf" + "e\u0301" + "\" + x"
+	l := New(input)
+
+	str := l.NextToken()
+	if str.Type != token.STRING {
+		t.Fatalf("expected STRING, got %v", str.Type)
+	}
+	want := "\"caf" + "e\u0301" + "\""
+	if str.Lexeme != want {
+		t.Fatalf("expected combining mark preserved in lexeme, got %q", str.Lexeme)
+	}
+
+	plus := l.NextToken()
+	if plus.Type != token.PLUS {
+		t.Fatalf("expected PLUS after string, got %v", plus.Type)
+	}
+
+	ident := l.NextToken()
+	if ident.Type != token.IDENTIFIER || ident.Lexeme != "x" {
+		t.Fatalf("expected identifier %q, got %v %q", "x", ident.Type, ident.Lexeme)
+	}
+}
+
+func TestNextTokenStripsLeadingBOM(t *testing.T) {
+	l := New("\uFEFFx")
+	tok := l.NextToken()
+	if tok.Type != token.IDENTIFIER || tok.Lexeme != "x" {
+		t.Fatalf("expected identifier %q, got %v %q", "x", tok.Type, tok.Lexeme)
+	}
+	if tok.StartOffset != 0 {
+		t.Fatalf("expected BOM stripped so token starts at offset 0, got %d", tok.StartOffset)
+	}
+}
+
+func TestNextTokenLineAndColumn(t *testing.T) {
+	l := New("x\n  y")
+
+	tok := l.NextToken()
+	if tok.Line != 1 || tok.Column != 1 {
+		t.Fatalf("expected x at 1:1, got %d:%d", tok.Line, tok.Column)
+	}
+
+	tok = l.NextToken()
+	if tok.Line != 2 || tok.Column != 3 {
+		t.Fatalf("expected y at 2:3, got %d:%d", tok.Line, tok.Column)
+	}
+}
+
+// TestCloseDrainsUnreadTokens guards against the run goroutine blocking
+// forever on a send once a caller stops reading before EOF: Close must
+// finish promptly even though most tokens are never passed to NextToken.
+func TestCloseDrainsUnreadTokens(t *testing.T) {
+	input := strings.Repeat("x ", 1000)
+	l := New(input)
+
+	l.NextToken() // read exactly one token, leaving the rest buffered/unread
+
+	done := make(chan struct{})
+	go func() {
+		l.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not return; run goroutine is likely blocked sending to l.tokens")
+	}
+}
+
+// TestCloseWithoutNextToken checks Close is safe to call on a lexer that
+// never had NextToken invoked on it at all.
+func TestCloseWithoutNextToken(t *testing.T) {
+	l := New("x y z")
+
+	done := make(chan struct{})
+	go func() {
+		l.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not return for an unread lexer")
+	}
+}
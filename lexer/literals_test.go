@@ -0,0 +1,126 @@
+package lexer
+
+import (
+	"testing"
+
+	"github.com/vomolo/interpreter/token"
+)
+
+func TestNextTokenStringEscapes(t *testing.T) {
+	l := New(`"a\nb\t\"c\\dé"`)
+	tok := l.NextToken()
+	if tok.Type != token.STRING {
+		t.Fatalf("expected STRING, got %v", tok.Type)
+	}
+	want := "a\nb\t\"c\\dé"
+	if tok.Literal != want {
+		t.Fatalf("expected decoded literal %q, got %q", want, tok.Literal)
+	}
+}
+
+func TestNextTokenFloat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"3.14", 3.14},
+		{"1.0e10", 1.0e10},
+		{"1e+5", 1e5},
+		{"2E-3", 2e-3},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+		if tok.Type != token.FLOAT {
+			t.Fatalf("input %q: expected FLOAT, got %v", tt.input, tok.Type)
+		}
+		if tok.Literal != tt.want {
+			t.Fatalf("input %q: expected literal %v, got %v", tt.input, tt.want, tok.Literal)
+		}
+	}
+}
+
+func TestNextTokenInteger(t *testing.T) {
+	l := New("42")
+	tok := l.NextToken()
+	if tok.Type != token.NUMBER {
+		t.Fatalf("expected NUMBER, got %v", tok.Type)
+	}
+	if tok.Literal != int64(42) {
+		t.Fatalf("expected literal 42, got %v", tok.Literal)
+	}
+}
+
+func TestNextTokenMalformedNumbers(t *testing.T) {
+	tests := []string{"1.2.3", "1e", "1."}
+
+	for _, input := range tests {
+		l := New(input)
+		tok := l.NextToken()
+		if tok.Type != token.ERROR {
+			t.Fatalf("input %q: expected ERROR, got %v (lexeme %q)", input, tok.Type, tok.Lexeme)
+		}
+		if tok.Line == 0 {
+			t.Fatalf("input %q: expected ERROR token to carry a line number", input)
+		}
+	}
+}
+
+// TestNextTokenMalformedNumberColumnAtEOF guards against a bug where next()
+// left lastLine/lastCol stale in its eof branch, so backup() after the peek
+// that discovers end-of-input restored a pre-EOF position instead of the
+// true column one past the last rune.
+func TestNextTokenMalformedNumberColumnAtEOF(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantColumn int
+	}{
+		{"1.", 3},
+		{"1e", 3},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+		if tok.Type != token.ERROR {
+			t.Fatalf("input %q: expected ERROR, got %v (lexeme %q)", tt.input, tok.Type, tok.Lexeme)
+		}
+		if tok.Column != tt.wantColumn {
+			t.Fatalf("input %q: expected Column %d, got %d", tt.input, tt.wantColumn, tok.Column)
+		}
+	}
+}
+
+func TestNextTokenUnterminatedStringReportsPosition(t *testing.T) {
+	l := New("x\n\"oops")
+	l.NextToken() // x
+
+	tok := l.NextToken()
+	if tok.Type != token.ERROR {
+		t.Fatalf("expected ERROR, got %v", tok.Type)
+	}
+	if tok.Line != 2 || tok.Column == 0 {
+		t.Fatalf("expected error positioned on line 2 with a column, got %d:%d", tok.Line, tok.Column)
+	}
+}
+
+func TestNextTokenKeywords(t *testing.T) {
+	tests := []struct {
+		input string
+		want  token.Type
+	}{
+		{"true", token.TRUE},
+		{"false", token.FALSE},
+		{"nil", token.NIL},
+		{"var", token.VAR},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+		if tok.Type != tt.want {
+			t.Fatalf("input %q: expected %v, got %v", tt.input, tt.want, tok.Type)
+		}
+	}
+}
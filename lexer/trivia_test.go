@@ -0,0 +1,61 @@
+package lexer
+
+import (
+	"testing"
+
+	"github.com/vomolo/interpreter/token"
+)
+
+func TestNextTokenCommentsAreSkippedByDefault(t *testing.T) {
+	l := New("x // trailing comment\n/* block */ y")
+
+	x := l.NextToken()
+	if x.Type != token.IDENTIFIER || x.Lexeme != "x" {
+		t.Fatalf("expected identifier x, got %v %q", x.Type, x.Lexeme)
+	}
+
+	y := l.NextToken()
+	if y.Type != token.IDENTIFIER || y.Lexeme != "y" {
+		t.Fatalf("expected identifier y, got %v %q", y.Type, y.Lexeme)
+	}
+}
+
+func TestNextTokenPreserveTriviaEmitsWhitespaceAndComments(t *testing.T) {
+	l := New("x /* c */ y")
+	l.PreserveTrivia(true)
+
+	want := []token.Type{
+		token.IDENTIFIER, token.WHITESPACE, token.COMMENT, token.WHITESPACE, token.IDENTIFIER,
+	}
+	for i, wantType := range want {
+		tok := l.NextToken()
+		if tok.Type != wantType {
+			t.Fatalf("token %d: want %v, got %v (lexeme %q)", i, wantType, tok.Type, tok.Lexeme)
+		}
+	}
+}
+
+func TestNextTokenNestedBlockComment(t *testing.T) {
+	l := New("/* outer /* inner */ still comment */ x")
+
+	tok := l.NextToken()
+	if tok.Type != token.IDENTIFIER || tok.Lexeme != "x" {
+		t.Fatalf("expected nested comment to be fully consumed, got %v %q", tok.Type, tok.Lexeme)
+	}
+}
+
+func TestNextTokenUnterminatedBlockComment(t *testing.T) {
+	l := New("/* never closed")
+	tok := l.NextToken()
+	if tok.Type != token.ERROR {
+		t.Fatalf("expected ERROR, got %v", tok.Type)
+	}
+}
+
+func TestNextTokenLineCommentStopsAtNewline(t *testing.T) {
+	l := New("// comment\nx")
+	tok := l.NextToken()
+	if tok.Type != token.IDENTIFIER || tok.Lexeme != "x" {
+		t.Fatalf("expected identifier x after line comment, got %v %q", tok.Type, tok.Lexeme)
+	}
+}
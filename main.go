@@ -2,136 +2,36 @@ package main
 
 import (
 	"fmt"
-	"unicode"
-)
-
-// TokenType represents the type of token.
-type TokenType int
+	"os"
 
-const (
-	EOF TokenType = iota
-	IDENTIFIER
-	NUMBER
-	PLUS
-	MINUS
-	ASTERISK
-	SLASH
-	LPAREN
-	RPAREN
+	"github.com/vomolo/interpreter/eval"
+	"github.com/vomolo/interpreter/lexer"
+	"github.com/vomolo/interpreter/parser"
 )
 
-// Token represents a lexical token.
-type Token struct {
-	Type    TokenType
-	Lexeme  string
-	Literal interface{}
-	Line    int
-}
-
-// Lexer represents a lexical analyzer.
-type Lexer struct {
-	input                string
-	start, current, line int
-}
-
-// NewLexer initializes a new lexer.
-func NewLexer(input string) *Lexer {
-	return &Lexer{input: input}
-}
-
-// NextToken returns the next token from the input.
-func (l *Lexer) NextToken() Token {
-	l.skipWhitespace()
-
-	if l.isAtEnd() {
-		return Token{Type: EOF, Lexeme: "", Line: l.line}
-	}
-
-	l.start = l.current
-
-	char := l.advance()
-
-	switch {
-	case unicode.IsLetter(char):
-		return l.identifier()
-	case unicode.IsDigit(char):
-		return l.number()
-	case char == '+':
-		return l.makeToken(PLUS)
-	case char == '-':
-		return l.makeToken(MINUS)
-	case char == '*':
-		return l.makeToken(ASTERISK)
-	case char == '/':
-		return l.makeToken(SLASH)
-	case char == '(':
-		return l.makeToken(LPAREN)
-	case char == ')':
-		return l.makeToken(RPAREN)
-	}
-
-	return Token{Type: EOF, Lexeme: "", Line: l.line}
-}
-
-// Helper methods
-func (l *Lexer) advance() rune {
-	l.current++
-	return rune(l.input[l.current-1])
-}
+func main() {
+	input := "var x = 42 + 3 * (y - 5)"
 
-func (l *Lexer) isAtEnd() bool {
-	return l.current >= len(l.input)
-}
+	l := lexer.New(input)
+	defer l.Close()
+	p := parser.New(l)
 
-func (l *Lexer) skipWhitespace() {
-	for !l.isAtEnd() {
-		char := l.peek()
-		switch char {
-		case ' ', '\r', '\t':
-			l.advance()
-		case '\n':
-			l.line++
-			l.advance()
-		default:
-			return
+	program := p.ParseProgram()
+	if errs := p.Errors(); len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintln(os.Stderr, e)
 		}
+		os.Exit(1)
 	}
-}
 
-func (l *Lexer) peek() rune {
-	if l.isAtEnd() {
-		return 0
-	}
-	return rune(l.input[l.current])
-}
+	env := eval.NewEnvironment()
+	env.Set("y", int64(10))
 
-func (l *Lexer) identifier() Token {
-	for unicode.IsLetter(l.peek()) || unicode.IsDigit(l.peek()) {
-		l.advance()
+	result, err := eval.Eval(program, env)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	return Token{Type: IDENTIFIER, Lexeme: l.input[l.start:l.current], Line: l.line}
-}
 
-func (l *Lexer) number() Token {
-	for unicode.IsDigit(l.peek()) {
-		l.advance()
-	}
-	return Token{Type: NUMBER, Lexeme: l.input[l.start:l.current], Line: l.line}
-}
-
-func (l *Lexer) makeToken(tokenType TokenType) Token {
-	return Token{Type: tokenType, Lexeme: l.input[l.start:l.current], Line: l.line}
-}
-
-func main() {
-	input := "var x = 42 + 3 * (y - 5)"
-	lexer := NewLexer(input)
-
-	for {
-		token := lexer.NextToken()
-		if token.Type == EOF {
-			break
-		}
-		fmt.Printf("Token: %v\n", token)
-	}
+	fmt.Printf("x = %v\n", result)
 }
@@ -0,0 +1,252 @@
+// Package parser implements a Pratt (precedence-climbing) parser that turns
+// a stream of tokens from the lexer into an AST.
+package parser
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/vomolo/interpreter/ast"
+	"github.com/vomolo/interpreter/lexer"
+	"github.com/vomolo/interpreter/token"
+)
+
+// Operator precedence levels.
+const (
+	LOWEST  = 0
+	SUM     = 10 // + -
+	PRODUCT = 20 // * /
+	PREFIX  = 30 // -x
+)
+
+var precedences = map[token.Type]int{
+	token.PLUS:     SUM,
+	token.MINUS:    SUM,
+	token.ASTERISK: PRODUCT,
+	token.SLASH:    PRODUCT,
+}
+
+type (
+	prefixParseFn func() ast.Expression
+	infixParseFn  func(ast.Expression) ast.Expression
+)
+
+// Parser consumes tokens from a Lexer and produces an AST.
+type Parser struct {
+	l *lexer.Lexer
+
+	curToken  token.Token
+	peekToken token.Token
+
+	errors []string
+
+	prefixParseFns map[token.Type]prefixParseFn
+	infixParseFns  map[token.Type]infixParseFn
+}
+
+// New creates a Parser reading from l.
+func New(l *lexer.Lexer) *Parser {
+	p := &Parser{l: l}
+
+	p.prefixParseFns = map[token.Type]prefixParseFn{
+		token.IDENTIFIER: p.parseIdentifier,
+		token.NUMBER:     p.parseNumberLiteral,
+		token.LPAREN:     p.parseGroupedExpression,
+		token.MINUS:      p.parseUnaryExpression,
+	}
+	p.infixParseFns = map[token.Type]infixParseFn{
+		token.PLUS:     p.parseBinaryExpression,
+		token.MINUS:    p.parseBinaryExpression,
+		token.ASTERISK: p.parseBinaryExpression,
+		token.SLASH:    p.parseBinaryExpression,
+	}
+
+	// Read two tokens so curToken and peekToken are both set.
+	p.nextToken()
+	p.nextToken()
+
+	return p
+}
+
+// Errors returns any errors accumulated while parsing.
+func (p *Parser) Errors() []string { return p.errors }
+
+func (p *Parser) nextToken() {
+	p.curToken = p.peekToken
+	p.peekToken = p.l.NextToken()
+}
+
+// ParseProgram parses the whole input and returns the root Program node.
+func (p *Parser) ParseProgram() *ast.Program {
+	program := &ast.Program{}
+
+	for p.curToken.Type != token.EOF {
+		stmt := p.parseStatement()
+		if stmt != nil {
+			program.Statements = append(program.Statements, stmt)
+		}
+		p.nextToken()
+	}
+
+	return program
+}
+
+func (p *Parser) parseStatement() ast.Statement {
+	switch p.curToken.Type {
+	case token.VAR:
+		// parseVarDecl returns a nil *ast.VarDecl on a parse error; returning
+		// that directly would box a nil pointer in a non-nil ast.Statement,
+		// which ParseProgram's "stmt != nil" check can't catch.
+		if decl := p.parseVarDecl(); decl != nil {
+			return decl
+		}
+		return nil
+	case token.IDENTIFIER:
+		if p.peekToken.Type == token.ASSIGN {
+			return p.parseAssignStmt()
+		}
+		return p.parseExpressionStmt()
+	default:
+		return p.parseExpressionStmt()
+	}
+}
+
+func (p *Parser) parseVarDecl() *ast.VarDecl {
+	decl := &ast.VarDecl{Token: p.curToken}
+
+	if !p.expectPeek(token.IDENTIFIER) {
+		return nil
+	}
+	decl.Name = &ast.Identifier{Token: p.curToken, Name: p.curToken.Lexeme}
+
+	if !p.expectPeek(token.ASSIGN) {
+		return nil
+	}
+
+	p.nextToken()
+	decl.Value = p.parseExpression(LOWEST)
+
+	p.skipSemicolon()
+
+	return decl
+}
+
+func (p *Parser) parseAssignStmt() *ast.AssignStmt {
+	name := &ast.Identifier{Token: p.curToken, Name: p.curToken.Lexeme}
+
+	p.nextToken() // consume identifier, curToken is now ASSIGN
+	stmt := &ast.AssignStmt{Token: p.curToken, Name: name}
+
+	p.nextToken()
+	stmt.Value = p.parseExpression(LOWEST)
+
+	p.skipSemicolon()
+
+	return stmt
+}
+
+func (p *Parser) parseExpressionStmt() *ast.ExpressionStmt {
+	stmt := &ast.ExpressionStmt{Token: p.curToken}
+	stmt.Expression = p.parseExpression(LOWEST)
+
+	p.skipSemicolon()
+
+	return stmt
+}
+
+func (p *Parser) skipSemicolon() {
+	if p.peekToken.Type == token.SEMICOLON {
+		p.nextToken()
+	}
+}
+
+func (p *Parser) parseExpression(precedence int) ast.Expression {
+	prefix := p.prefixParseFns[p.curToken.Type]
+	if prefix == nil {
+		p.errors = append(p.errors, fmt.Sprintf("line %d: no prefix parse function for %v", p.curToken.Line, p.curToken.Type))
+		return nil
+	}
+	left := prefix()
+
+	for p.peekToken.Type != token.SEMICOLON && precedence < p.peekPrecedence() {
+		infix := p.infixParseFns[p.peekToken.Type]
+		if infix == nil {
+			return left
+		}
+		p.nextToken()
+		left = infix(left)
+	}
+
+	return left
+}
+
+func (p *Parser) parseIdentifier() ast.Expression {
+	return &ast.Identifier{Token: p.curToken, Name: p.curToken.Lexeme}
+}
+
+func (p *Parser) parseNumberLiteral() ast.Expression {
+	lit := &ast.NumberLiteral{Token: p.curToken}
+
+	value, err := strconv.ParseInt(p.curToken.Lexeme, 10, 64)
+	if err != nil {
+		p.errors = append(p.errors, fmt.Sprintf("line %d: could not parse %q as integer", p.curToken.Line, p.curToken.Lexeme))
+		return nil
+	}
+	lit.Value = value
+
+	return lit
+}
+
+func (p *Parser) parseGroupedExpression() ast.Expression {
+	p.nextToken()
+
+	expr := p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	return expr
+}
+
+func (p *Parser) parseUnaryExpression() ast.Expression {
+	expr := &ast.UnaryExpr{Token: p.curToken, Operator: p.curToken.Lexeme}
+
+	p.nextToken()
+	expr.Right = p.parseExpression(PREFIX)
+
+	return expr
+}
+
+func (p *Parser) parseBinaryExpression(left ast.Expression) ast.Expression {
+	expr := &ast.BinaryExpr{Token: p.curToken, Operator: p.curToken.Lexeme, Left: left}
+
+	precedence := p.curPrecedence()
+	p.nextToken()
+	expr.Right = p.parseExpression(precedence)
+
+	return expr
+}
+
+func (p *Parser) peekPrecedence() int {
+	if prec, ok := precedences[p.peekToken.Type]; ok {
+		return prec
+	}
+	return LOWEST
+}
+
+func (p *Parser) curPrecedence() int {
+	if prec, ok := precedences[p.curToken.Type]; ok {
+		return prec
+	}
+	return LOWEST
+}
+
+func (p *Parser) expectPeek(t token.Type) bool {
+	if p.peekToken.Type == t {
+		p.nextToken()
+		return true
+	}
+	p.errors = append(p.errors, fmt.Sprintf("line %d: expected next token to be %v, got %v instead", p.peekToken.Line, t, p.peekToken.Type))
+	return false
+}
@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/vomolo/interpreter/ast"
+	"github.com/vomolo/interpreter/eval"
+	"github.com/vomolo/interpreter/lexer"
+)
+
+func TestVarDeclEvaluatesArithmetic(t *testing.T) {
+	l := lexer.New("var x = 42 + 3 * (y - 5)")
+	p := New(l)
+
+	program := p.ParseProgram()
+	if errs := p.Errors(); len(errs) > 0 {
+		t.Fatalf("parser errors: %v", errs)
+	}
+	if len(program.Statements) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(program.Statements))
+	}
+	if _, ok := program.Statements[0].(*ast.VarDecl); !ok {
+		t.Fatalf("expected *ast.VarDecl, got %T", program.Statements[0])
+	}
+
+	env := eval.NewEnvironment()
+	env.Set("y", int64(10))
+
+	result, err := eval.Eval(program, env)
+	if err != nil {
+		t.Fatalf("eval error: %v", err)
+	}
+	if result != int64(57) {
+		t.Fatalf("expected 57, got %v", result)
+	}
+
+	x, ok := env.Get("x")
+	if !ok || x != int64(57) {
+		t.Fatalf("expected x bound to 57, got %v (ok=%v)", x, ok)
+	}
+}
+
+func TestMalformedVarDeclDoesNotProduceNilStatement(t *testing.T) {
+	l := lexer.New("var 42 = 1")
+	p := New(l)
+
+	program := p.ParseProgram()
+	if errs := p.Errors(); len(errs) == 0 {
+		t.Fatalf("expected parser errors for malformed var declaration, got none")
+	}
+	for i, stmt := range program.Statements {
+		if stmt == nil {
+			t.Fatalf("statement %d is nil; parseStatement must not append a nil ast.Statement", i)
+		}
+	}
+
+	// A program built from a failed declaration must not panic on eval.
+	env := eval.NewEnvironment()
+	if _, err := eval.Eval(program, env); err == nil {
+		t.Fatalf("expected eval error for program built from a malformed declaration, got none")
+	}
+}
@@ -0,0 +1,113 @@
+// Package token defines the lexical token types produced by the lexer and
+// consumed by the parser.
+package token
+
+// Type represents the type of token.
+type Type int
+
+const (
+	EOF Type = iota
+	ERROR
+	IDENTIFIER
+	NUMBER
+	FLOAT
+	STRING
+	COMMENT    // line (//) or block (/* */) comment
+	WHITESPACE // only emitted when Lexer.PreserveTrivia(true) is set
+
+	// Keywords
+	VAR
+	TRUE
+	FALSE
+	NIL
+
+	PLUS
+	MINUS
+	ASTERISK
+	SLASH
+	LPAREN
+	RPAREN
+	ASSIGN
+	SEMICOLON
+
+	// Comparison and logical operators, several of which are multi-character
+	// and require the lexer to look ahead one rune before emitting.
+	EQ       // ==
+	NEQ      // !=
+	LT       // <
+	LTE      // <=
+	GT       // >
+	GTE      // >=
+	AND      // &&
+	OR       // ||
+	ELLIPSIS // ...
+)
+
+var typeNames = map[Type]string{
+	EOF:        "EOF",
+	ERROR:      "ERROR",
+	IDENTIFIER: "IDENTIFIER",
+	NUMBER:     "NUMBER",
+	FLOAT:      "FLOAT",
+	STRING:     "STRING",
+	COMMENT:    "COMMENT",
+	WHITESPACE: "WHITESPACE",
+	VAR:        "VAR",
+	TRUE:       "TRUE",
+	FALSE:      "FALSE",
+	NIL:        "NIL",
+	PLUS:       "PLUS",
+	MINUS:      "MINUS",
+	ASTERISK:   "ASTERISK",
+	SLASH:      "SLASH",
+	LPAREN:     "LPAREN",
+	RPAREN:     "RPAREN",
+	ASSIGN:     "ASSIGN",
+	SEMICOLON:  "SEMICOLON",
+	EQ:         "EQ",
+	NEQ:        "NEQ",
+	LT:         "LT",
+	LTE:        "LTE",
+	GT:         "GT",
+	GTE:        "GTE",
+	AND:        "AND",
+	OR:         "OR",
+	ELLIPSIS:   "ELLIPSIS",
+}
+
+// String returns the name of the token type, e.g. "IDENTIFIER".
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// keywords maps reserved words to their token type.
+var keywords = map[string]Type{
+	"var":   VAR,
+	"true":  TRUE,
+	"false": FALSE,
+	"nil":   NIL,
+}
+
+// LookupIdentifier returns the keyword token type for ident, or IDENTIFIER
+// if ident is not a reserved word.
+func LookupIdentifier(ident string) Type {
+	if tok, ok := keywords[ident]; ok {
+		return tok
+	}
+	return IDENTIFIER
+}
+
+// Token represents a lexical token.
+type Token struct {
+	Type    Type
+	Lexeme  string
+	Literal interface{}
+
+	Line        int // 1-based line of the token's first rune
+	Column      int // 1-based column (in runes) of the token's first rune
+	StartOffset int // byte offset of the token's first rune in the source
+	EndOffset   int // byte offset just past the token's last rune
+}